@@ -0,0 +1,121 @@
+package log
+
+// DropPolicy controls what happens when an asyncDispatcher's queue is
+// full.
+type DropPolicy int
+
+const (
+	// Block waits for room in the queue, applying backpressure to the
+	// caller.
+	Block DropPolicy = iota
+	// DropNewest discards the incoming entry when the queue is full.
+	DropNewest
+	// DropOldest discards the oldest queued entry to make room for the
+	// incoming one.
+	DropOldest
+)
+
+// asyncMsg is what actually travels through an asyncDispatcher's queue:
+// either a log entry, or a drain barrier. Folding both into the same
+// channel lets drain establish "everything enqueued before this point
+// has been dispatched" just by waiting for its own message to come back
+// round-tripped, with no separate counter shared across goroutines.
+type asyncMsg struct {
+	entry   Entry
+	barrier chan struct{} // non-nil for a drain barrier; closed once reached
+}
+
+// asyncDispatcher drains a buffered channel of entries on a single
+// background goroutine, so handler(e) can return to the caller without
+// waiting on potentially slow handlers (network sinks). Entries for a
+// given level are dispatched in the order they were sent.
+//
+// Entry values sent here are never mutated or recycled after send
+// returns, so a caller (including Msg) can enqueue and move on without
+// racing the background goroutine's read of the entry.
+type asyncDispatcher struct {
+	queue  chan asyncMsg
+	policy DropPolicy
+}
+
+func newAsyncDispatcher(bufferSize int, policy DropPolicy) *asyncDispatcher {
+	d := &asyncDispatcher{
+		queue:  make(chan asyncMsg, bufferSize),
+		policy: policy,
+	}
+
+	go d.run()
+	return d
+}
+
+func (d *asyncDispatcher) run() {
+	for m := range d.queue {
+		if m.barrier != nil {
+			close(m.barrier)
+			continue
+		}
+
+		dispatch(m.entry)
+	}
+}
+
+func (d *asyncDispatcher) send(e Entry) {
+	msg := asyncMsg{entry: e}
+
+	switch d.policy {
+	case DropNewest:
+		select {
+		case d.queue <- msg:
+		default:
+		}
+	case DropOldest:
+		for {
+			select {
+			case d.queue <- msg:
+				return
+			default:
+				select {
+				case old := <-d.queue:
+					// A drain barrier is never dropped: put it back
+					// so the drain waiting on it still returns.
+					if old.barrier != nil {
+						d.queue <- old
+					}
+				default:
+				}
+			}
+		}
+	default: // Block
+		d.queue <- msg
+	}
+}
+
+// drain blocks until every entry sent so far has been dispatched. It
+// does this by sending a barrier message and waiting for run to reach
+// it, rather than tracking an in-flight count: a shared counter would
+// need send (called from arbitrary goroutines) to increment it and
+// drain to wait on it hitting zero, which is exactly the Add-races-Wait
+// pattern sync.WaitGroup documents as unsupported.
+func (d *asyncDispatcher) drain() {
+	barrier := make(chan struct{})
+	d.queue <- asyncMsg{barrier: barrier}
+	<-barrier
+}
+
+// stop drains the dispatcher and closes its queue, letting run exit.
+// Used when SetAsync replaces an existing dispatcher, so the old one's
+// goroutine doesn't leak, blocked forever on a channel nobody sends to
+// anymore.
+func (d *asyncDispatcher) stop() {
+	d.drain()
+	close(d.queue)
+}
+
+// drainAsync blocks until l's async dispatcher, if any, has dispatched
+// every entry sent so far. Used before os.Exit so Fatal/Panic don't lose
+// logs still sitting in the async queue.
+func drainAsync(l *logger) {
+	if l.async != nil {
+		l.async.drain()
+	}
+}
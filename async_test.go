@@ -0,0 +1,88 @@
+package log
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type countingHandler struct {
+	mu    sync.Mutex
+	count int
+}
+
+func (h *countingHandler) Log(e Entry) error {
+	h.mu.Lock()
+	h.count++
+	h.mu.Unlock()
+	return nil
+}
+
+func (h *countingHandler) Count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count
+}
+
+func newTestLogger(h Handler, levels ...Level) *logger {
+	l := new()
+	for _, level := range levels {
+		l.leveledHandlers[level] = append(l.leveledHandlers[level], h)
+	}
+	l.cacheLeveledHandlers = l.getLeveledHandlers()
+	return l
+}
+
+// TestAsyncDispatcherDrain sends from many goroutines concurrently, then
+// drains (Flush's code path) and checks drain only returns once every
+// sent entry has actually reached the handler. Run with -race:
+// the WaitGroup-based precursor raced Add (from send) against Wait
+// (from drain) across goroutines, which sync.WaitGroup forbids.
+func TestAsyncDispatcherDrain(t *testing.T) {
+	h := &countingHandler{}
+	l := newTestLogger(h, InfoLevel)
+	l.async = newAsyncDispatcher(4, Block)
+
+	const goroutines = 50
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			newEntry(l).Info("hello")
+		}()
+	}
+	wg.Wait()
+
+	l.async.drain()
+
+	if got := h.Count(); got != goroutines {
+		t.Fatalf("drain returned before all entries were dispatched: got %d, want %d", got, goroutines)
+	}
+}
+
+// TestAsyncDispatcherStop checks that stopping a dispatcher drains its
+// queue and closes it, instead of leaking its background goroutine
+// blocked forever on a channel nobody sends to anymore.
+func TestAsyncDispatcherStop(t *testing.T) {
+	h := &countingHandler{}
+	l := newTestLogger(h, InfoLevel)
+	d := newAsyncDispatcher(4, Block)
+	l.async = d
+
+	newEntry(l).Info("hello")
+	d.stop()
+
+	if got := h.Count(); got != 1 {
+		t.Fatalf("stop did not drain pending entries: got %d, want 1", got)
+	}
+
+	select {
+	case _, ok := <-d.queue:
+		if ok {
+			t.Fatal("queue should be closed after stop")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("queue was not closed after stop")
+	}
+}
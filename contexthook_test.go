@@ -0,0 +1,45 @@
+package log
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+// TestRegisterContextHookConcurrent registers hooks and dispatches
+// context-carrying entries concurrently. Run with -race: contextHooks
+// used to be a bare slice appended to by RegisterContextHook with no
+// locking while dispatch concurrently ranged over it.
+func TestRegisterContextHookConcurrent(t *testing.T) {
+	h := &countingHandler{}
+	l := newTestLogger(h, InfoLevel)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			i := i
+			RegisterContextHook(func(context.Context) Fields {
+				return Fields{"i": i}
+			})
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			e := newEntry(l)
+			e.ctx = ctx
+			e.Info("hello")
+		}
+	}()
+
+	wg.Wait()
+
+	if got := h.Count(); got != 100 {
+		t.Fatalf("got %d dispatched entries, want 100", got)
+	}
+}
@@ -1,6 +1,7 @@
 package log
 
 import (
+	"context"
 	"fmt"
 	stdlog "log"
 	"os"
@@ -28,11 +29,23 @@ func (f Fields) Get(name string) interface{} {
 	return f[name]
 }
 
+// fieldKV is a single typed key/value pair appended by the typed field
+// helpers (Str, Int, ...). Keeping the caller's original value instead
+// of re-encoding it means Entry.Fields reports the value back with its
+// original Go type (an int stays an int) instead of round-tripping it
+// through a text format that would widen it.
+type fieldKV struct {
+	key string
+	val interface{}
+}
+
 // Entry defines a single log entry
 type Entry struct {
 	logger *logger
 	start  time.Time
-	fields []Fields // private used; store all fields when withFields is called.  improve performance.
+	fields []Fields        // private used; store all fields when withFields is called.  improve performance.
+	kv     []fieldKV       // private; fields appended via the typed helpers (Str, Int, ...)
+	ctx    context.Context // set by FromContext; used to run ContextHooks on dispatch
 
 	Level     Level     `json:"level"`
 	Message   string    `json:"message"`
@@ -108,6 +121,7 @@ func (e Entry) Panic(msg string) {
 	e.Level = PanicLevel
 	e.Message = msg
 	handler(e)
+	drainAsync(e.logger)
 	os.Exit(1)
 }
 
@@ -116,6 +130,7 @@ func (e Entry) Panicf(msg string, v ...interface{}) {
 	e.Level = PanicLevel
 	e.Message = fmt.Sprintf(msg, v...)
 	handler(e)
+	drainAsync(e.logger)
 	os.Exit(1)
 }
 
@@ -124,6 +139,7 @@ func (e Entry) Fatal(msg string) {
 	e.Level = FatalLevel
 	e.Message = msg
 	handler(e)
+	drainAsync(e.logger)
 	os.Exit(1)
 }
 
@@ -132,77 +148,102 @@ func (e Entry) Fatalf(msg string, v ...interface{}) {
 	e.Level = FatalLevel
 	e.Message = fmt.Sprintf(msg, v...)
 	handler(e)
+	drainAsync(e.logger)
 	os.Exit(1)
 }
 
+// Msg dispatches the entry to the registered handlers for its current
+// Level, using msg as the message. It is a lighter-weight terminator
+// than the level-specific methods (Debug, Info, ...) for chains built
+// entirely from the typed field helpers, which don't otherwise set a
+// Level on their own — call WithLevel first, e.g.
+// log.Int("count", 3).WithLevel(log.InfoLevel).Msg("processed").
+func (e Entry) Msg(msg string) {
+	e.Message = msg
+	handler(e)
+}
+
 // Str add string field to current entry
 func (e Entry) Str(key string, val string) Entry {
-	return e.WithFields(Fields{key: val})
+	return e.withKV(key, val)
 }
 
 // Bool add bool field to current entry
 func (e Entry) Bool(key string, val bool) Entry {
-	return e.WithFields(Fields{key: val})
+	return e.withKV(key, val)
 }
 
 // Int add Int field to current entry
 func (e Entry) Int(key string, val int) Entry {
-	return e.WithFields(Fields{key: val})
+	return e.withKV(key, val)
 }
 
 // Int8 add Int8 field to current entry
 func (e Entry) Int8(key string, val int8) Entry {
-	return e.WithFields(Fields{key: val})
+	return e.withKV(key, val)
 }
 
 // Int16 add Int16 field to current entry
 func (e Entry) Int16(key string, val int16) Entry {
-	return e.WithFields(Fields{key: val})
+	return e.withKV(key, val)
 }
 
 // Int32 add Int32 field to current entry
 func (e Entry) Int32(key string, val int32) Entry {
-	return e.WithFields(Fields{key: val})
+	return e.withKV(key, val)
 }
 
 // Int64 add Int64 field to current entry
 func (e Entry) Int64(key string, val int64) Entry {
-	return e.WithFields(Fields{key: val})
+	return e.withKV(key, val)
 }
 
 // Uint add Uint field to current entry
 func (e Entry) Uint(key string, val uint) Entry {
-	return e.WithFields(Fields{key: val})
+	return e.withKV(key, val)
 }
 
 // Uint8 add Uint8 field to current entry
 func (e Entry) Uint8(key string, val uint8) Entry {
-	return e.WithFields(Fields{key: val})
+	return e.withKV(key, val)
 }
 
 // Uint16 add Uint16 field to current entry
 func (e Entry) Uint16(key string, val uint16) Entry {
-	return e.WithFields(Fields{key: val})
+	return e.withKV(key, val)
 }
 
 // Uint32 add Uint32 field to current entry
 func (e Entry) Uint32(key string, val uint32) Entry {
-	return e.WithFields(Fields{key: val})
+	return e.withKV(key, val)
 }
 
 // Uint64 add Uint64 field to current entry
 func (e Entry) Uint64(key string, val uint64) Entry {
-	return e.WithFields(Fields{key: val})
+	return e.withKV(key, val)
 }
 
 // Float32 add Float32 field to current entry
 func (e Entry) Float32(key string, val float32) Entry {
-	return e.WithFields(Fields{key: val})
+	return e.withKV(key, val)
 }
 
 // Float64 add Float64 field to current entry
 func (e Entry) Float64(key string, val float64) Entry {
-	return e.WithFields(Fields{key: val})
+	return e.withKV(key, val)
+}
+
+// withKV returns a copy of e with key/val appended to kv. Like
+// WithFields, it always allocates a fresh slice (here sized exactly to
+// the new length), so an entry reused as a base for several independent chains
+// (e.g. base := log.Str("request_id", id); base.Int(...); base.Int(...))
+// never shares a backing array across them — appending to one can't
+// overwrite another.
+func (e Entry) withKV(key string, val interface{}) Entry {
+	kv := make([]fieldKV, len(e.kv), len(e.kv)+1)
+	copy(kv, e.kv)
+	e.kv = append(kv, fieldKV{key: key, val: val})
+	return e
 }
 
 // WithField returns a new entry with the `key` and `value` set.
@@ -228,6 +269,15 @@ func (e Entry) WithError(err error) Entry {
 	return e.WithField("error", fmt.Sprintf("%+v", err))
 }
 
+// WithLevel returns a new entry with Level set to l. It's mainly useful
+// before Msg for entries built from the typed field helpers (Str, Int,
+// ...), which — unlike Debug/Info/Warn/Error — don't imply a Level on
+// their own.
+func (e Entry) WithLevel(l Level) Entry {
+	e.Level = l
+	return e
+}
+
 // Trace returns a new entry with a Stop method to fire off
 // a corresponding completion log, useful with defer.
 func (e Entry) Trace(msg string) Entry {
@@ -236,7 +286,9 @@ func (e Entry) Trace(msg string) Entry {
 	return e
 }
 
-// mergedFields returns the fields list collapsed into a single map.
+// mergedFields returns the fields list collapsed into a single map,
+// including fields written by the typed helpers (Str, Int, ...), with
+// each value keeping its original Go type.
 func (e Entry) mergedFields() Fields {
 	f := Fields{}
 
@@ -246,6 +298,10 @@ func (e Entry) mergedFields() Fields {
 		}
 	}
 
+	for _, kv := range e.kv {
+		f[kv.key] = kv.val
+	}
+
 	return f
 }
 
@@ -254,6 +310,13 @@ const (
 	year = 365 * day
 )
 
+// Duration formats d the same way Stop formats the elapsed time of a
+// Trace, so handlers outside this package can render durations
+// consistently.
+func Duration(d time.Duration) string {
+	return duration(d)
+}
+
 func duration(d time.Duration) string {
 	if d < day {
 		return d.String()
@@ -281,6 +344,18 @@ func (e Entry) Stop() {
 }
 
 func handler(e Entry) {
+	if e.logger.async != nil {
+		e.logger.async.send(e)
+		return
+	}
+
+	dispatch(e)
+}
+
+// dispatch invokes the handlers registered for e.Level. It runs inline
+// on the caller's goroutine, or on the async dispatcher's background
+// goroutine when SetAsync is in effect.
+func dispatch(e Entry) {
 	// I guess we don't need to lock here and the performance can be improved
 	// e.logger.rwMutex.RLock()
 	// defer e.logger.rwMutex.RUnlock()
@@ -288,6 +363,11 @@ func handler(e Entry) {
 	for _, h := range e.logger.cacheLeveledHandlers(e.Level) {
 		e.Timestamp = time.Now().UTC()
 		e.Fields = e.mergedFields()
+		if e.ctx != nil {
+			for k, v := range runContextHooks(e.ctx) {
+				e.Fields[k] = v
+			}
+		}
 		err := h.Log(e)
 		if err != nil {
 			stdlog.Printf("log: log failed: %v", err)
@@ -0,0 +1,26 @@
+package log
+
+import "testing"
+
+// BenchmarkFieldsMap measures the old approach the typed helpers used
+// to build on: a fresh Fields map per field, wrapped in a WithFields
+// call.
+func BenchmarkFieldsMap(b *testing.B) {
+	e := newEntry(_logger)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = e.WithFields(Fields{"count": i}).WithFields(Fields{"user": "alice"})
+	}
+}
+
+// BenchmarkTypedKV measures the current typed helpers, which append to
+// a plain []fieldKV instead of allocating a map per field.
+func BenchmarkTypedKV(b *testing.B) {
+	e := newEntry(_logger)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = e.Int("count", i).Str("user", "alice")
+	}
+}
@@ -0,0 +1,147 @@
+// Package console implements a log.Handler that renders entries for
+// interactive terminals: colorized per-level output, aligned fields,
+// and compact duration formatting. It falls back to plain text when
+// writing to something other than a terminal, or when NO_COLOR is set.
+package console
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jasonsoft/log"
+)
+
+// ANSI color codes used for the supported levels.
+const (
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorYellow = "\033[33m"
+	colorBlue   = "\033[34m"
+	colorCyan   = "\033[36m"
+	colorGray   = "\033[90m"
+)
+
+// messageWidth is the column width the message is padded to when fields
+// follow it, so that fields start in the same column.
+const messageWidth = 44
+
+var levelColors = map[log.Level]string{
+	log.DebugLevel: colorGray,
+	log.InfoLevel:  colorBlue,
+	log.WarnLevel:  colorYellow,
+	log.ErrorLevel: colorRed,
+	log.PanicLevel: colorRed,
+	log.FatalLevel: colorRed,
+}
+
+// Handler renders entries to Writer for interactive use.
+type Handler struct {
+	Writer     io.Writer
+	Color      bool
+	TimeFormat string
+
+	colorSet bool // true once WithColor has run, so auto-detection in New doesn't override it
+}
+
+// Option configures a Handler created by New.
+type Option func(*Handler)
+
+// WithColor forces color output on or off, overriding the
+// terminal/NO_COLOR auto-detection New performs.
+func WithColor(enabled bool) Option {
+	return func(h *Handler) {
+		h.Color = enabled
+		h.colorSet = true
+	}
+}
+
+// WithTimeFormat sets the time.Format layout used to render the entry
+// timestamp. The default is time.Kitchen.
+func WithTimeFormat(layout string) Option {
+	return func(h *Handler) {
+		h.TimeFormat = layout
+	}
+}
+
+// WithWriter sets the destination the handler writes to. The default
+// is os.Stderr.
+func WithWriter(w io.Writer) Option {
+	return func(h *Handler) {
+		h.Writer = w
+	}
+}
+
+// New returns a Handler that writes human-readable entries to w
+// (os.Stderr by default), with color enabled automatically when w is a
+// terminal that supports it.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		Writer:     os.Stderr,
+		TimeFormat: time.Kitchen,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	if !h.colorSet {
+		h.Color = false
+		if f, ok := h.Writer.(*os.File); ok {
+			h.Color = isTerminal(f) && enableANSI(f.Fd())
+		}
+		if os.Getenv("NO_COLOR") != "" {
+			h.Color = false
+		}
+	}
+
+	return h
+}
+
+// Log implements log.Handler.
+func (h *Handler) Log(e log.Entry) error {
+	var b strings.Builder
+
+	ts := e.Timestamp.Format(h.TimeFormat)
+	level := strings.ToUpper(fmt.Sprintf("%v", e.Level))
+
+	// Pad the message so the fields of consecutive entries line up.
+	msg := e.Message
+	if len(e.Fields) > 0 {
+		msg = fmt.Sprintf("%-*s", messageWidth, msg)
+	}
+
+	if h.Color {
+		fmt.Fprintf(&b, "%s%s%s %s%-5s%s %s", colorCyan, ts, colorReset, levelColors[e.Level], level, colorReset, msg)
+	} else {
+		fmt.Fprintf(&b, "%s %-5s %s", ts, level, msg)
+	}
+
+	for _, name := range e.Fields.Names() {
+		val := e.Fields.Get(name)
+		if d, ok := val.(time.Duration); ok {
+			val = log.Duration(d)
+		}
+
+		if h.Color {
+			fmt.Fprintf(&b, " %s%s=%s%v", colorGray, name, colorReset, val)
+		} else {
+			fmt.Fprintf(&b, " %s=%v", name, val)
+		}
+	}
+
+	b.WriteByte('\n')
+	_, err := io.WriteString(h.Writer, b.String())
+	return err
+}
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return fi.Mode()&os.ModeCharDevice != 0
+}
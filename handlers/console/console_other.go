@@ -0,0 +1,9 @@
+//go:build !windows
+
+package console
+
+// enableANSI is a no-op on non-Windows platforms, whose terminals
+// already interpret ANSI escape sequences natively.
+func enableANSI(fd uintptr) bool {
+	return true
+}
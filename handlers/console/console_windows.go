@@ -0,0 +1,32 @@
+//go:build windows
+
+package console
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+const enableVirtualTerminalProcessing = 0x0004
+
+var (
+	kernel32           = syscall.NewLazyDLL("kernel32.dll")
+	procGetConsoleMode = kernel32.NewProc("GetConsoleMode")
+	procSetConsoleMode = kernel32.NewProc("SetConsoleMode")
+)
+
+// enableANSI turns on VT100 escape sequence processing for fd so ANSI
+// color codes render correctly in cmd.exe and legacy Windows consoles.
+// It returns false if fd isn't a console or VT processing can't be
+// enabled, in which case the handler falls back to plain text.
+func enableANSI(fd uintptr) bool {
+	var mode uint32
+	r, _, _ := procGetConsoleMode.Call(fd, uintptr(unsafe.Pointer(&mode)))
+	if r == 0 {
+		return false
+	}
+
+	mode |= enableVirtualTerminalProcessing
+	r, _, _ = procSetConsoleMode.Call(fd, uintptr(mode))
+	return r != 0
+}
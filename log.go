@@ -27,6 +27,7 @@ type logger struct {
 	cacheLeveledHandlers func(level Level) []Handler
 	defaultFields        []Fields
 	rwMutex              sync.RWMutex
+	async                *asyncDispatcher
 }
 
 func new() *logger {
@@ -81,6 +82,25 @@ func RegisterHandler(handler Handler, levels ...Level) {
 	_logger.cacheLeveledHandlers = _logger.getLeveledHandlers()
 }
 
+// SetAsync switches the logger to asynchronous dispatch: handlers are
+// invoked from a background goroutine draining a channel of buffered
+// entries, instead of inline on the caller's goroutine. bufferSize sets
+// the channel capacity and policy controls what happens once it fills
+// up. Fatal and Panic still drain the queue synchronously before
+// exiting, so exit-time logs aren't lost, and Flush drains it before
+// flushing each Flusher. Calling SetAsync again drains and shuts down
+// the previous dispatcher's goroutine before installing the new one.
+func SetAsync(bufferSize int, policy DropPolicy) {
+	_logger.rwMutex.Lock()
+	prev := _logger.async
+	_logger.async = newAsyncDispatcher(bufferSize, policy)
+	_logger.rwMutex.Unlock()
+
+	if prev != nil {
+		prev.stop()
+	}
+}
+
 // Debug level formatted message.
 func Debug(msg string) {
 	e := newEntry(_logger)
@@ -239,6 +259,10 @@ func Float64(key string, val float64) Entry {
 
 // Flush clear all handler's buffer
 func Flush() {
+	if _logger.async != nil {
+		_logger.async.drain()
+	}
+
 	for _, h := range _logger.handles {
 		flusher, ok := h.(Flusher)
 		if ok {
@@ -297,12 +321,69 @@ func NewContext(ctx context.Context, e Entry) context.Context {
 	return context.WithValue(ctx, ctxKey, e)
 }
 
-// FromContext return a logger from the context
+// FromContext return a logger from the context. The returned Entry
+// carries ctx along, so ContextHooks registered with RegisterContextHook
+// run against it when the Entry is dispatched.
 func FromContext(ctx context.Context) Entry {
+	var e Entry
+
 	v := ctx.Value(ctxKey)
 	if v == nil {
-		return newEntry(_logger)
+		e = newEntry(_logger)
+	} else {
+		e = v.(Entry)
 	}
 
-	return v.(Entry)
+	e.ctx = ctx
+	return e
+}
+
+// ContextHook is invoked with the context carried by an Entry when that
+// Entry is dispatched, and contributes additional fields. It's meant for
+// values that live on the context rather than in fields the caller
+// attached explicitly, such as a request ID or an OpenTelemetry span.
+type ContextHook func(context.Context) Fields
+
+var (
+	contextHooksMu sync.RWMutex
+	contextHooks   []ContextHook
+)
+
+// RegisterContextHook adds a hook that runs for every Entry carrying a
+// context (i.e. obtained via FromContext or the *Ctx helpers) when it's
+// dispatched to the registered handlers.
+func RegisterContextHook(hook ContextHook) {
+	contextHooksMu.Lock()
+	defer contextHooksMu.Unlock()
+
+	contextHooks = append(contextHooks, hook)
+}
+
+// runContextHooks returns the fields contributed by every registered
+// ContextHook for ctx.
+func runContextHooks(ctx context.Context) Fields {
+	contextHooksMu.RLock()
+	hooks := contextHooks
+	contextHooksMu.RUnlock()
+
+	f := Fields{}
+	for _, hook := range hooks {
+		for k, v := range hook(ctx) {
+			f[k] = v
+		}
+	}
+
+	return f
+}
+
+// InfoCtx level message, with fields contributed by ctx (both fields
+// attached via NewContext and any registered ContextHooks) merged in.
+func InfoCtx(ctx context.Context, msg string) {
+	FromContext(ctx).Info(msg)
+}
+
+// ErrorCtx level message, with fields contributed by ctx (both fields
+// attached via NewContext and any registered ContextHooks) merged in.
+func ErrorCtx(ctx context.Context, msg string) {
+	FromContext(ctx).Error(msg)
 }
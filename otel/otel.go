@@ -0,0 +1,31 @@
+// Package otel provides a log.ContextHook that correlates log entries
+// with OpenTelemetry traces.
+package otel
+
+import (
+	"context"
+
+	"github.com/jasonsoft/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Hook returns a log.ContextHook that reads the active span from ctx
+// and emits trace_id, span_id, and trace_flags fields, so logs can be
+// correlated with the trace that produced them. It emits no fields when
+// ctx carries no valid span.
+//
+//	log.RegisterContextHook(otel.Hook())
+func Hook() log.ContextHook {
+	return func(ctx context.Context) log.Fields {
+		sc := trace.SpanContextFromContext(ctx)
+		if !sc.IsValid() {
+			return nil
+		}
+
+		return log.Fields{
+			"trace_id":    sc.TraceID().String(),
+			"span_id":     sc.SpanID().String(),
+			"trace_flags": sc.TraceFlags().String(),
+		}
+	}
+}
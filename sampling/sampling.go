@@ -0,0 +1,147 @@
+// Package sampling provides composable log.Handler middlewares that
+// reduce the volume of entries forwarded to a wrapped handler. They are
+// meant to sit in front of high-cost sinks (Graylog, Syslog, a network
+// socket) registered with log.RegisterHandler, so a burst of Debug or
+// Info calls on a hot path doesn't overwhelm them:
+//
+//	log.RegisterHandler(sampling.NewBurstSampler(myHandler, 100, time.Second), log.InfoLevel)
+package sampling
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jasonsoft/log"
+)
+
+// NewSampler returns a log.Handler that forwards only 1 in every
+// entries to h, counting across all levels. every == 0 is treated as 1
+// (forward everything).
+func NewSampler(h log.Handler, every uint32) log.Handler {
+	if every == 0 {
+		every = 1
+	}
+
+	return &sampler{handler: h, every: every}
+}
+
+type sampler struct {
+	handler log.Handler
+	every   uint32
+	counter uint32
+}
+
+func (s *sampler) Log(e log.Entry) error {
+	n := atomic.AddUint32(&s.counter, 1)
+	if n%s.every != 0 {
+		return nil
+	}
+
+	return s.handler.Log(e)
+}
+
+func (s *sampler) Flush() error {
+	return flush(s.handler)
+}
+
+// NewBurstSampler returns a log.Handler that forwards up to burst
+// entries to h per period, dropping the rest, then resets the count at
+// the start of the next period.
+func NewBurstSampler(h log.Handler, burst int, period time.Duration) log.Handler {
+	return &burstSampler{handler: h, burst: int32(burst), period: period}
+}
+
+type burstSampler struct {
+	handler log.Handler
+	burst   int32
+	period  time.Duration
+
+	mu       sync.Mutex
+	count    int32
+	resetsAt time.Time
+}
+
+func (b *burstSampler) Log(e log.Entry) error {
+	b.mu.Lock()
+	now := time.Now().UTC()
+	if now.After(b.resetsAt) {
+		b.resetsAt = now.Add(b.period)
+		b.count = 0
+	}
+	b.count++
+	allow := b.count <= b.burst
+	b.mu.Unlock()
+
+	if !allow {
+		return nil
+	}
+
+	return b.handler.Log(e)
+}
+
+func (b *burstSampler) Flush() error {
+	return flush(b.handler)
+}
+
+// LevelPolicy pairs a Level with the sample rate applied to entries at
+// that level: 1 in every Every entries is forwarded.
+type LevelPolicy struct {
+	Level log.Level
+	Every uint32
+}
+
+// NewLevelSampler returns a log.Handler that applies a per-level
+// sampling rate to h, as given by policies. Levels with no policy are
+// always forwarded.
+func NewLevelSampler(h log.Handler, policies ...LevelPolicy) log.Handler {
+	every := make(map[log.Level]uint32, len(policies))
+	counters := make(map[log.Level]*uint32, len(policies))
+
+	for _, policy := range policies {
+		rate := policy.Every
+		if rate == 0 {
+			rate = 1
+		}
+
+		every[policy.Level] = rate
+		counters[policy.Level] = new(uint32)
+	}
+
+	return &levelSampler{handler: h, every: every, counters: counters}
+}
+
+type levelSampler struct {
+	handler  log.Handler
+	every    map[log.Level]uint32
+	counters map[log.Level]*uint32
+}
+
+func (s *levelSampler) Log(e log.Entry) error {
+	every, ok := s.every[e.Level]
+	if !ok {
+		return s.handler.Log(e)
+	}
+
+	n := atomic.AddUint32(s.counters[e.Level], 1)
+	if n%every != 0 {
+		return nil
+	}
+
+	return s.handler.Log(e)
+}
+
+func (s *levelSampler) Flush() error {
+	return flush(s.handler)
+}
+
+// flush forwards Flush to h when it implements log.Flusher, so wrapping
+// a flushable handler in a sampler doesn't prevent log.Flush from
+// draining it.
+func flush(h log.Handler) error {
+	if f, ok := h.(log.Flusher); ok {
+		return f.Flush()
+	}
+
+	return nil
+}
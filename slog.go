@@ -0,0 +1,122 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// SlogHandler adapts this package's handler pipeline to the standard
+// library's log/slog.Handler interface. It lets libraries that emit
+// through slog funnel their records into handlers registered with
+// RegisterHandler (Graylog, Syslog, ...) instead of running a second,
+// duplicate pipeline.
+type SlogHandler struct {
+	logger *logger
+	groups []string
+	fields []Fields
+}
+
+// SlogOption configures a SlogHandler returned by NewSlogHandler.
+type SlogOption func(*SlogHandler)
+
+// NewSlogHandler returns an slog.Handler that translates slog.Record
+// values into this package's Entry type and dispatches them through the
+// handlers registered with RegisterHandler. Level filtering is honored:
+// Enabled reports false for a level with no registered handlers, so
+// disabled levels short-circuit before a record is ever built.
+func NewSlogHandler(opts ...SlogOption) *SlogHandler {
+	h := &SlogHandler{
+		logger: _logger,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Enabled reports whether at least one handler is registered for the
+// Level corresponding to lvl.
+func (h *SlogHandler) Enabled(_ context.Context, lvl slog.Level) bool {
+	return len(h.logger.cacheLeveledHandlers(fromSlogLevel(lvl))) > 0
+}
+
+// Handle converts r into an Entry and dispatches it to the handlers
+// registered for its Level.
+func (h *SlogHandler) Handle(_ context.Context, r slog.Record) error {
+	e := newEntry(h.logger)
+	e.fields = append(e.fields, h.fields...)
+
+	fields := Fields{}
+	r.Attrs(func(a slog.Attr) bool {
+		addSlogAttr(fields, h.groups, a)
+		return true
+	})
+	if len(fields) > 0 {
+		e.fields = append(e.fields, fields)
+	}
+
+	e.Level = fromSlogLevel(r.Level)
+	e.Message = r.Message
+	handler(e)
+	return nil
+}
+
+// WithAttrs returns a new SlogHandler that includes attrs on every
+// subsequent record.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := Fields{}
+	for _, a := range attrs {
+		addSlogAttr(fields, h.groups, a)
+	}
+
+	clone := *h
+	clone.fields = append(append([]Fields{}, h.fields...), fields)
+	return &clone
+}
+
+// WithGroup returns a new SlogHandler that nests subsequent attrs under
+// name, producing dotted field keys such as "group.key".
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.groups = append(append([]string{}, h.groups...), name)
+	return &clone
+}
+
+// AsSlogLogger returns an *slog.Logger backed by the handlers
+// registered with RegisterHandler, so code written against log/slog can
+// share this package's handler pipeline instead of configuring its own.
+func AsSlogLogger() *slog.Logger {
+	return slog.New(NewSlogHandler())
+}
+
+func addSlogAttr(fields Fields, groups []string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		for _, ga := range a.Value.Group() {
+			addSlogAttr(fields, append(groups, a.Key), ga)
+		}
+		return
+	}
+
+	key := a.Key
+	if len(groups) > 0 {
+		key = strings.Join(append(append([]string{}, groups...), a.Key), ".")
+	}
+	fields[key] = a.Value.Any()
+}
+
+func fromSlogLevel(lvl slog.Level) Level {
+	switch {
+	case lvl < slog.LevelInfo:
+		return DebugLevel
+	case lvl < slog.LevelWarn:
+		return InfoLevel
+	case lvl < slog.LevelError:
+		return WarnLevel
+	default:
+		return ErrorLevel
+	}
+}